@@ -15,26 +15,32 @@
 package fileserver
 
 import (
+	"encoding/base64"
 	"encoding/json"
 	"flag"
+	"fmt"
 	"log"
+	"os"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/caddyserver/caddy/v2"
 	"github.com/caddyserver/caddy/v2/caddyconfig"
 	caddycmd "github.com/caddyserver/caddy/v2/cmd"
 	"github.com/caddyserver/caddy/v2/modules/caddyhttp"
+	"github.com/caddyserver/caddy/v2/modules/caddyhttp/caddyauth"
 	caddytpl "github.com/caddyserver/caddy/v2/modules/caddyhttp/templates"
 	"github.com/caddyserver/certmagic"
 	"go.uber.org/zap"
+	"golang.org/x/crypto/bcrypt"
 )
 
 func init() {
 	caddycmd.RegisterCommand(caddycmd.Command{
 		Name:  "file-server",
 		Func:  cmdFileServer,
-		Usage: "[--domain <example.com>] [--root <path>] [--listen <addr>] [--browse] [--access-log]",
+		Usage: "[--domain <example.com>] [--root <path>] [--listen <addr>] [--browse] [--access-log] [--webdav --username <user> --password <pass>]",
 		Short: "Spins up a production-ready file server",
 		Long: `
 A simple but production-ready file server. Useful for quick deployments,
@@ -48,7 +54,20 @@ a public domain, ensure A/AAAA records are properly configured before
 using this option.
 
 If --browse is enabled, requests for folders without an index file will
-respond with a file listing.`,
+respond with a file listing.
+
+If --webdav is enabled, the server also accepts WebDAV requests (PROPFIND,
+PROPPATCH, MKCOL, COPY, MOVE, LOCK, UNLOCK, DELETE, and PUT) against --root,
+turning the file server into a read/write endpoint; GET and HEAD requests
+keep going to the regular static file (and --browse/--templates) handling.
+Because this exposes writable storage, --webdav requires credentials: set
+--username and --password, or the CADDY_WEBDAV_USERNAME and
+CADDY_WEBDAV_PASSWORD environment variables.
+
+If --precompressed is given a comma-separated list of encodings (from br,
+zstd, and gzip), the file server will prefer serving a precompressed
+".br"/".zst"/".gz" sibling of a matching file when the request's
+Accept-Encoding allows it, in the listed order of preference.`,
 		Flags: func() *flag.FlagSet {
 			fs := flag.NewFlagSet("file-server", flag.ExitOnError)
 			fs.String("domain", "", "Domain name at which to serve the files")
@@ -59,6 +78,10 @@ respond with a file listing.`,
 			fs.Bool("templates", false, "Enable template rendering")
 			fs.Bool("access-log", false, "Enable the access log")
 			fs.Bool("debug", false, "Enable verbose debug logs")
+			fs.Bool("webdav", false, "Enable read/write WebDAV access to root")
+			fs.String("username", "", "Username required for WebDAV (or set CADDY_WEBDAV_USERNAME)")
+			fs.String("password", "", "Password required for WebDAV (or set CADDY_WEBDAV_PASSWORD)")
+			fs.String("precompressed", "", "Comma-separated precompressed encodings to prefer, e.g. br,zstd,gzip")
 			return fs
 		}(),
 	})
@@ -75,9 +98,45 @@ func cmdFileServer(fs caddycmd.Flags) (int, error) {
 	templates := fs.Bool("templates")
 	accessLog := fs.Bool("access-log")
 	debug := fs.Bool("debug")
+	webdavEnabled := fs.Bool("webdav")
+	username := fs.String("username")
+	password := fs.String("password")
+	precompressed := fs.String("precompressed")
 
 	var handlers []json.RawMessage
 
+	if webdavEnabled {
+		if username == "" {
+			username = os.Getenv("CADDY_WEBDAV_USERNAME")
+		}
+		if password == "" {
+			password = os.Getenv("CADDY_WEBDAV_PASSWORD")
+		}
+		if username == "" || password == "" {
+			return caddy.ExitCodeFailedStartup, fmt.Errorf("webdav requires credentials: set --username and --password, or CADDY_WEBDAV_USERNAME and CADDY_WEBDAV_PASSWORD")
+		}
+		hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+		if err != nil {
+			return caddy.ExitCodeFailedStartup, err
+		}
+		auth := caddyauth.Authentication{
+			ProvidersRaw: caddy.ModuleMap{
+				"http_basic": caddyconfig.JSON(caddyauth.HTTPBasicAuth{
+					HashRaw:     caddyconfig.JSONModuleObject(caddyauth.BcryptHash{}, "algorithm", "bcrypt", nil),
+					AccountList: []caddyauth.Account{
+						{Username: username, Password: base64.StdEncoding.EncodeToString(hash)},
+					},
+				}, nil),
+			},
+		}
+		handlers = append(handlers, caddyconfig.JSONModuleObject(auth, "handler", "authentication", nil))
+		webdavRoot := root
+		if webdavRoot == "" {
+			webdavRoot = "."
+		}
+		handlers = append(handlers, caddyconfig.JSONModuleObject(WebDAV{Root: webdavRoot}, "handler", "webdav", nil))
+	}
+
 	if templates {
 		handler := caddytpl.Templates{FileRoot: root}
 		handlers = append(handlers, caddyconfig.JSONModuleObject(handler, "handler", "templates", nil))
@@ -87,6 +146,18 @@ func cmdFileServer(fs caddycmd.Flags) (int, error) {
 	if browse {
 		handler.Browse = new(Browse)
 	}
+	if precompressed != "" {
+		// The resolver step, range-against-variant serving, variant-derived
+		// ETag/Last-Modified, and hiding of precompressed siblings in Browse
+		// are all handled by FileServer/Browse themselves once
+		// PrecompressedOrder is set; this flag only has to build that config.
+		precompress, order, err := parsePrecompressedFlag(precompressed)
+		if err != nil {
+			return caddy.ExitCodeFailedStartup, err
+		}
+		handler.PrecompressedRaw = precompress
+		handler.PrecompressedOrder = order
+	}
 	handlers = append(handlers, caddyconfig.JSONModuleObject(handler, "handler", "file_server", nil))
 
 	route := caddyhttp.Route{HandlersRaw: handlers}
@@ -151,3 +222,25 @@ func cmdFileServer(fs caddycmd.Flags) (int, error) {
 
 	select {}
 }
+
+// parsePrecompressedFlag parses the comma-separated --precompressed flag
+// value (e.g. "br,zstd,gzip") into a module map, suitable for
+// FileServer.PrecompressedRaw, and the preference order derived from it.
+// Each name is resolved against the registered http.precompressed.* modules,
+// the same way the `precompressed` Caddyfile subdirective does.
+func parsePrecompressedFlag(raw string) (caddy.ModuleMap, []string, error) {
+	parts := strings.Split(raw, ",")
+	order := make([]string, len(parts))
+	for i, encoding := range parts {
+		order[i] = strings.TrimSpace(encoding)
+	}
+	precompress := make(caddy.ModuleMap)
+	for _, encoding := range order {
+		modInfo, err := caddy.GetModule("http.precompressed." + encoding)
+		if err != nil {
+			return nil, order, fmt.Errorf("unrecognized precompressed encoding %q: %v", encoding, err)
+		}
+		precompress[encoding] = caddyconfig.JSON(modInfo.New(), nil)
+	}
+	return precompress, order, nil
+}
@@ -0,0 +1,33 @@
+// Copyright 2015 Matthew Holt and The Caddy Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fileserver
+
+import "testing"
+
+func TestParsePrecompressedFlagUnrecognizedEncoding(t *testing.T) {
+	_, _, err := parsePrecompressedFlag("zzz")
+	if err == nil {
+		t.Fatal("expected an error for an unrecognized encoding, got nil")
+	}
+}
+
+func TestParsePrecompressedFlagTrimsWhitespace(t *testing.T) {
+	// The http.precompressed.* modules may not be registered in this test
+	// binary, so only the order normalization (not the error) is asserted.
+	_, order, _ := parsePrecompressedFlag(" br , zstd ")
+	if len(order) != 2 || order[0] != "br" || order[1] != "zstd" {
+		t.Fatalf("expected trimmed order [br zstd], got %v", order)
+	}
+}
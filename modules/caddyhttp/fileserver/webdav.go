@@ -0,0 +1,111 @@
+// Copyright 2015 Matthew Holt and The Caddy Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fileserver
+
+import (
+	"net/http"
+
+	"go.uber.org/zap"
+	"golang.org/x/net/webdav"
+
+	"github.com/caddyserver/caddy/v2"
+	"github.com/caddyserver/caddy/v2/modules/caddyhttp"
+)
+
+func init() {
+	caddy.RegisterModule(WebDAV{})
+}
+
+// webdavMethods are the HTTP methods that belong to WebDAV rather than to
+// ordinary GET-style static file serving, so they're the only ones claimed
+// by WebDAV; everything else (notably GET and HEAD) is passed through to
+// the next handler, which is typically file_server.
+var webdavMethods = map[string]bool{
+	"PROPFIND":  true,
+	"PROPPATCH": true,
+	"MKCOL":     true,
+	"COPY":      true,
+	"MOVE":      true,
+	"LOCK":      true,
+	"UNLOCK":    true,
+
+	http.MethodPut:     true,
+	http.MethodDelete:  true,
+	http.MethodOptions: true, // so clients (Finder, Windows) can probe DAV support
+}
+
+// WebDAV implements a handler that serves the WebDAV protocol (RFC 4918) on
+// top of a directory tree, turning it into a read/write endpoint. Requests
+// using WebDAV-specific methods (PROPFIND, PROPPATCH, MKCOL, COPY, MOVE,
+// LOCK, UNLOCK, DELETE, and PUT), as well as OPTIONS, are handled directly;
+// any other method is
+// passed through to the next handler, so WebDAV can sit in front of
+// file_server (and whatever it has configured, like Browse) to serve plain
+// GETs from the same root.
+//
+// Because this handler can create, overwrite, and delete files, it should
+// almost always be paired with an authentication handler in front of it.
+type WebDAV struct {
+	// Root is the path to the root of the file system WebDAV clients may
+	// read from and write to. Default is ".", the current directory.
+	Root string `json:"root,omitempty"`
+
+	handler *webdav.Handler
+	logger  *zap.Logger
+}
+
+// CaddyModule returns the Caddy module information.
+func (WebDAV) CaddyModule() caddy.ModuleInfo {
+	return caddy.ModuleInfo{
+		ID:  "http.handlers.webdav",
+		New: func() caddy.Module { return new(WebDAV) },
+	}
+}
+
+// Provision sets up the WebDAV handler.
+func (w *WebDAV) Provision(ctx caddy.Context) error {
+	w.logger = ctx.Logger()
+	if w.Root == "" {
+		w.Root = "."
+	}
+	w.handler = &webdav.Handler{
+		FileSystem: webdav.Dir(w.Root),
+		LockSystem: webdav.NewMemLS(),
+		Logger: func(r *http.Request, err error) {
+			if err != nil {
+				w.logger.Error("webdav",
+					zap.String("method", r.Method),
+					zap.String("path", r.URL.Path),
+					zap.Error(err))
+			}
+		},
+	}
+	return nil
+}
+
+// ServeHTTP implements caddyhttp.MiddlewareHandler.
+func (w *WebDAV) ServeHTTP(rw http.ResponseWriter, r *http.Request, next caddyhttp.Handler) error {
+	if !webdavMethods[r.Method] {
+		return next.ServeHTTP(rw, r)
+	}
+	w.handler.ServeHTTP(rw, r)
+	return nil
+}
+
+// Interface guards
+var (
+	_ caddy.Provisioner           = (*WebDAV)(nil)
+	_ caddyhttp.MiddlewareHandler = (*WebDAV)(nil)
+)